@@ -0,0 +1,288 @@
+package helper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultMaxOffendingResources bounds how many offending resources AggregateManifestConditions
+// lists by name in its generated message before falling back to "...".
+const defaultMaxOffendingResources = 3
+
+// aggregateStrategy picks how the per-manifest statuses for a condition type are rolled up
+// into a single aggregate status.
+type aggregateStrategy int
+
+const (
+	// strategyAnyFalseIsFalse aggregates to False if any manifest is False, True if every
+	// manifest is True, and Unknown otherwise. This is the default.
+	strategyAnyFalseIsFalse aggregateStrategy = iota
+	// strategyAllTrueIsTrue aggregates to True only if every manifest is True, and False
+	// otherwise (Unknown manifests count against True).
+	strategyAllTrueIsTrue
+	// strategyMajorityWins aggregates to whichever of True/False/Unknown has the most
+	// manifests, breaking ties in favor of Unknown.
+	strategyMajorityWins
+	// strategyAnyTrueIsTrue aggregates to True if any manifest is True, False if every
+	// manifest is explicitly False, and Unknown otherwise. This is the mirror image of
+	// strategyAnyFalseIsFalse for negative-polarity condition types (e.g. Degraded), where a
+	// single offending manifest should taint the whole ManifestWork rather than be averaged
+	// away.
+	strategyAnyTrueIsTrue
+)
+
+// AggregateOption customizes the behavior of AggregateManifestConditions.
+type AggregateOption func(*aggregateConfig)
+
+type aggregateConfig struct {
+	strategy              aggregateStrategy
+	maxOffendingResources int
+}
+
+// AnyFalseIsFalse aggregates to False if any manifest's condition is False, to True if every
+// manifest's condition is True, and to Unknown otherwise.
+func AnyFalseIsFalse() AggregateOption {
+	return func(c *aggregateConfig) {
+		c.strategy = strategyAnyFalseIsFalse
+	}
+}
+
+// AllTrueIsTrue aggregates to True only when every manifest's condition is True.
+func AllTrueIsTrue() AggregateOption {
+	return func(c *aggregateConfig) {
+		c.strategy = strategyAllTrueIsTrue
+	}
+}
+
+// MajorityWins aggregates to whichever status more manifests report than either other status
+// (not necessarily more than half of them); ties aggregate to Unknown.
+func MajorityWins() AggregateOption {
+	return func(c *aggregateConfig) {
+		c.strategy = strategyMajorityWins
+	}
+}
+
+// AnyTrueIsTrue aggregates to True if any manifest's condition is True, to False if every
+// manifest's condition is explicitly False, and to Unknown otherwise. Use this for
+// negative-polarity condition types such as Degraded, where AnyFalseIsFalse would report
+// False as soon as any healthy manifest says False, hiding a single offending manifest.
+func AnyTrueIsTrue() AggregateOption {
+	return func(c *aggregateConfig) {
+		c.strategy = strategyAnyTrueIsTrue
+	}
+}
+
+// WithMaxOffendingResources caps how many offending resources are named in the aggregated
+// condition's message before it falls back to "...".
+func WithMaxOffendingResources(max int) AggregateOption {
+	return func(c *aggregateConfig) {
+		c.maxOffendingResources = max
+	}
+}
+
+// manifestStatus is one manifest's contribution to an aggregate: its resource identity and
+// the status/reason of the condition being aggregated (Unknown/"ConditionNotFound" if the
+// manifest never reported that condition type at all).
+type manifestStatus struct {
+	ordinal  int32
+	resource string
+	status   metav1.ConditionStatus
+	reason   string
+}
+
+// AggregateManifestConditions rolls the per-manifest conditions of the given type up into a
+// single top-level StatusCondition, using the chosen AggregateOption strategy (AnyFalseIsFalse
+// by default). When the aggregate is not True, the resulting condition's Message
+// deterministically lists the manifests not reporting True, e.g. "3 of 5 manifests are not
+// Available: resource1[0], resource2[1], ..."; when it is True, the Message only counts the
+// manifests reporting True, e.g. "2 of 3 manifests are Available".
+func AggregateManifestConditions(conds []workapiv1.ManifestCondition, conditionType string, opts ...AggregateOption) workapiv1.StatusCondition {
+	cfg := &aggregateConfig{strategy: strategyAnyFalseIsFalse, maxOffendingResources: defaultMaxOffendingResources}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	statuses := make([]manifestStatus, 0, len(conds))
+	for _, mc := range conds {
+		status := metav1.ConditionUnknown
+		reason := "ConditionNotFound"
+		if cond := FindStatusCondition(mc.Conditions, conditionType); cond != nil {
+			status = cond.Status
+			reason = cond.Reason
+		}
+		statuses = append(statuses, manifestStatus{
+			ordinal:  mc.ResourceMeta.Ordinal,
+			resource: mc.ResourceMeta.Resource,
+			status:   status,
+			reason:   reason,
+		})
+	}
+
+	aggregatedStatus := aggregateStatus(statuses, cfg.strategy)
+	return workapiv1.StatusCondition{
+		Type:    conditionType,
+		Status:  aggregatedStatus,
+		Reason:  aggregateReason(conditionType, aggregatedStatus, statuses),
+		Message: aggregateMessage(conditionType, aggregatedStatus, statuses, cfg.maxOffendingResources),
+	}
+}
+
+func aggregateStatus(statuses []manifestStatus, strategy aggregateStrategy) metav1.ConditionStatus {
+	var trueCount, falseCount, unknownCount int
+	for _, s := range statuses {
+		switch s.status {
+		case metav1.ConditionTrue:
+			trueCount++
+		case metav1.ConditionFalse:
+			falseCount++
+		default:
+			unknownCount++
+		}
+	}
+
+	switch strategy {
+	case strategyAllTrueIsTrue:
+		if trueCount == len(statuses) && len(statuses) > 0 {
+			return metav1.ConditionTrue
+		}
+		return metav1.ConditionFalse
+	case strategyMajorityWins:
+		switch {
+		case trueCount > falseCount && trueCount > unknownCount:
+			return metav1.ConditionTrue
+		case falseCount > trueCount && falseCount > unknownCount:
+			return metav1.ConditionFalse
+		default:
+			return metav1.ConditionUnknown
+		}
+	case strategyAnyTrueIsTrue:
+		switch {
+		case trueCount > 0:
+			return metav1.ConditionTrue
+		case falseCount == len(statuses) && len(statuses) > 0:
+			return metav1.ConditionFalse
+		default:
+			return metav1.ConditionUnknown
+		}
+	default: // strategyAnyFalseIsFalse
+		switch {
+		case falseCount > 0:
+			return metav1.ConditionFalse
+		case trueCount == len(statuses) && len(statuses) > 0:
+			return metav1.ConditionTrue
+		default:
+			return metav1.ConditionUnknown
+		}
+	}
+}
+
+// aggregateReason derives the aggregate Reason from the winning (status, reason) bucket: the
+// most common reason among the manifests that share the aggregated status, so that e.g. a
+// False Available condition driven by ten "ResourceNotFound" manifests and one
+// "ConnectionRefused" manifest is reported as "ResourceNotFound" rather than a generic
+// catch-all. Ties are broken alphabetically for determinism.
+func aggregateReason(conditionType string, status metav1.ConditionStatus, statuses []manifestStatus) string {
+	if reason := winningReason(statuses, status); reason != "" {
+		return reason
+	}
+	if status == metav1.ConditionTrue {
+		return fmt.Sprintf("All%sManifestsTrue", conditionType)
+	}
+	return fmt.Sprintf("Some%sManifestsNotTrue", conditionType)
+}
+
+// winningReason buckets statuses by (status, reason) and returns the reason with the most
+// members among those matching status, or "" if no manifest has that status.
+func winningReason(statuses []manifestStatus, status metav1.ConditionStatus) string {
+	counts := map[string]int{}
+	reasons := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		if s.status != status || s.reason == "" {
+			continue
+		}
+		if _, seen := counts[s.reason]; !seen {
+			reasons = append(reasons, s.reason)
+		}
+		counts[s.reason]++
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+
+	sort.Strings(reasons)
+	winner := reasons[0]
+	for _, reason := range reasons[1:] {
+		if counts[reason] > counts[winner] {
+			winner = reason
+		}
+	}
+	return winner
+}
+
+func aggregateMessage(conditionType string, status metav1.ConditionStatus, statuses []manifestStatus, max int) string {
+	if status == metav1.ConditionTrue {
+		trueCount := 0
+		for _, s := range statuses {
+			if s.status == metav1.ConditionTrue {
+				trueCount++
+			}
+		}
+		return fmt.Sprintf("%d of %d manifests are %s", trueCount, len(statuses), conditionType)
+	}
+
+	offending := make([]manifestStatus, 0, len(statuses))
+	for _, s := range statuses {
+		if s.status != metav1.ConditionTrue {
+			offending = append(offending, s)
+		}
+	}
+	sort.Slice(offending, func(i, j int) bool { return offending[i].ordinal < offending[j].ordinal })
+
+	listed := offending
+	truncated := false
+	if len(listed) > max {
+		listed = listed[:max]
+		truncated = true
+	}
+
+	names := make([]string, 0, len(listed))
+	for _, s := range listed {
+		names = append(names, fmt.Sprintf("%s[%d]", s.resource, s.ordinal))
+	}
+	if truncated {
+		names = append(names, "...")
+	}
+
+	return fmt.Sprintf("%d of %d manifests are not %s: %s", len(offending), len(statuses), conditionType, strings.Join(names, ", "))
+}
+
+// topLevelConditionTypes are the ManifestWork-level conditions SummarizeManifestWorkStatus
+// derives from the per-manifest conditions, together with the rollup strategy appropriate to
+// each condition type's polarity.
+var topLevelConditionTypes = []struct {
+	conditionType string
+	opts          []AggregateOption
+}{
+	{conditionType: "Applied"},
+	{conditionType: "Available"},
+	// Degraded is negative-polarity (True is bad), so a single degraded manifest must taint
+	// the whole ManifestWork rather than be outvoted by healthy ones.
+	{conditionType: "Degraded", opts: []AggregateOption{AnyTrueIsTrue()}},
+}
+
+// SummarizeManifestWorkStatus rolls status.ManifestConditions up into the top-level Applied,
+// Available and Degraded conditions on status, writing each back via SetStatusCondition so
+// that an unchanged aggregate keeps its LastTransitionTime. observedGeneration is stamped onto
+// each aggregated condition, the same as any other UpdateManifestWorkStatusFunc is expected to
+// do, so consumers can tell whether the rollup reflects the current spec or a stale one.
+func SummarizeManifestWorkStatus(status *workapiv1.ManifestWorkStatus, observedGeneration int64) {
+	for _, t := range topLevelConditionTypes {
+		aggregated := AggregateManifestConditions(status.ManifestConditions, t.conditionType, t.opts...)
+		aggregated.ObservedGeneration = observedGeneration
+		SetStatusCondition(&status.Conditions, aggregated)
+	}
+}
@@ -0,0 +1,231 @@
+package helper
+
+import (
+	"testing"
+
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestAggregateManifestConditions tests AggregateManifestConditions
+func TestAggregateManifestConditions(t *testing.T) {
+	cases := []struct {
+		name            string
+		conditionType   string
+		conds           []workapiv1.ManifestCondition
+		opts            []AggregateOption
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:          "all true",
+			conditionType: "Available",
+			conds: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "resource1", newCondition("Available", "True", "my-reason", "my-message", nil)),
+				newManifestCondition(1, "resource2", newCondition("Available", "True", "my-reason", "my-message", nil)),
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "my-reason",
+			expectedMessage: "2 of 2 manifests are Available",
+		},
+		{
+			name:          "any false is false by default",
+			conditionType: "Available",
+			conds: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "resource1", newCondition("Available", "True", "my-reason", "my-message", nil)),
+				newManifestCondition(1, "resource2", newCondition("Available", "False", "ResourceNotFound", "my-message", nil)),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "ResourceNotFound",
+			expectedMessage: "1 of 2 manifests are not Available: resource2[1]",
+		},
+		{
+			name:          "reason reflects the winning bucket, not just a generic label",
+			conditionType: "Available",
+			conds: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "resource1", newCondition("Available", "False", "ResourceNotFound", "my-message", nil)),
+				newManifestCondition(1, "resource2", newCondition("Available", "False", "ResourceNotFound", "my-message", nil)),
+				newManifestCondition(2, "resource3", newCondition("Available", "False", "ConnectionRefused", "my-message", nil)),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "ResourceNotFound",
+			expectedMessage: "3 of 3 manifests are not Available: resource1[0], resource2[1], resource3[2]",
+		},
+		{
+			name:          "missing condition counts as unknown",
+			conditionType: "Available",
+			conds: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "resource1", newCondition("Available", "True", "my-reason", "my-message", nil)),
+				newManifestCondition(1, "resource2"),
+			},
+			expectedStatus:  metav1.ConditionUnknown,
+			expectedReason:  "ConditionNotFound",
+			expectedMessage: "1 of 2 manifests are not Available: resource2[1]",
+		},
+		{
+			name:          "message truncates past the offending resource cap",
+			conditionType: "Available",
+			conds: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "resource1", newCondition("Available", "False", "my-reason", "my-message", nil)),
+				newManifestCondition(1, "resource2", newCondition("Available", "False", "my-reason", "my-message", nil)),
+				newManifestCondition(2, "resource3", newCondition("Available", "False", "my-reason", "my-message", nil)),
+				newManifestCondition(3, "resource4", newCondition("Available", "False", "my-reason", "my-message", nil)),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "my-reason",
+			expectedMessage: "4 of 4 manifests are not Available: resource1[0], resource2[1], resource3[2], ...",
+		},
+		{
+			name:          "AllTrueIsTrue treats unknown as not true",
+			conditionType: "Available",
+			conds: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "resource1", newCondition("Available", "True", "my-reason", "my-message", nil)),
+				newManifestCondition(1, "resource2"),
+			},
+			opts:            []AggregateOption{AllTrueIsTrue()},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "SomeAvailableManifestsNotTrue",
+			expectedMessage: "1 of 2 manifests are not Available: resource2[1]",
+		},
+		{
+			name:          "MajorityWins picks the larger bucket",
+			conditionType: "Available",
+			conds: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "resource1", newCondition("Available", "True", "my-reason", "my-message", nil)),
+				newManifestCondition(1, "resource2", newCondition("Available", "True", "my-reason", "my-message", nil)),
+				newManifestCondition(2, "resource3", newCondition("Available", "False", "my-reason", "my-message", nil)),
+			},
+			opts:            []AggregateOption{MajorityWins()},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "my-reason",
+			expectedMessage: "2 of 3 manifests are Available",
+		},
+		{
+			name:          "AnyTrueIsTrue lets one degraded manifest taint the rollup",
+			conditionType: "Degraded",
+			conds: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "resource1", newCondition("Degraded", "False", "AsExpected", "my-message", nil)),
+				newManifestCondition(1, "resource2", newCondition("Degraded", "False", "AsExpected", "my-message", nil)),
+				newManifestCondition(2, "resource3", newCondition("Degraded", "True", "CrashLoopBackOff", "my-message", nil)),
+			},
+			opts:            []AggregateOption{AnyTrueIsTrue()},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  "CrashLoopBackOff",
+			expectedMessage: "1 of 3 manifests are Degraded",
+		},
+		{
+			name:          "AnyTrueIsTrue is False when every manifest explicitly says so",
+			conditionType: "Degraded",
+			conds: []workapiv1.ManifestCondition{
+				newManifestCondition(0, "resource1", newCondition("Degraded", "False", "AsExpected", "my-message", nil)),
+				newManifestCondition(1, "resource2", newCondition("Degraded", "False", "AsExpected", "my-message", nil)),
+			},
+			opts:            []AggregateOption{AnyTrueIsTrue()},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  "AsExpected",
+			expectedMessage: "2 of 2 manifests are not Degraded: resource1[0], resource2[1]",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			aggregated := AggregateManifestConditions(c.conds, c.conditionType, c.opts...)
+			if aggregated.Status != c.expectedStatus {
+				t.Errorf("expected status %q, got %q", c.expectedStatus, aggregated.Status)
+			}
+			if aggregated.Reason != c.expectedReason {
+				t.Errorf("expected reason %q, got %q", c.expectedReason, aggregated.Reason)
+			}
+			if aggregated.Message != c.expectedMessage {
+				t.Errorf("expected message %q, got %q", c.expectedMessage, aggregated.Message)
+			}
+		})
+	}
+}
+
+// TestSummarizeManifestWorkStatus tests SummarizeManifestWorkStatus
+func TestSummarizeManifestWorkStatus(t *testing.T) {
+	status := &workapiv1.ManifestWorkStatus{
+		ManifestConditions: []workapiv1.ManifestCondition{
+			newManifestCondition(0, "resource1",
+				newCondition("Applied", "True", "AppliedManifestComplete", "my-message", nil),
+				newCondition("Available", "True", "ResourceAvailable", "my-message", nil),
+				newCondition("Degraded", "False", "AsExpected", "my-message", nil),
+			),
+			newManifestCondition(1, "resource2",
+				newCondition("Applied", "True", "AppliedManifestComplete", "my-message", nil),
+				newCondition("Available", "False", "ResourceNotFound", "my-message", nil),
+				newCondition("Degraded", "True", "CrashLoopBackOff", "my-message", nil),
+			),
+		},
+	}
+
+	SummarizeManifestWorkStatus(status, 5)
+
+	applied := FindStatusCondition(status.Conditions, "Applied")
+	if applied == nil || applied.Status != metav1.ConditionTrue {
+		t.Fatalf("expected Applied condition to be True, got %+v", applied)
+	}
+	if applied.ObservedGeneration != 5 {
+		t.Fatalf("expected Applied ObservedGeneration to be 5, got %d", applied.ObservedGeneration)
+	}
+
+	available := FindStatusCondition(status.Conditions, "Available")
+	if available == nil || available.Status != metav1.ConditionFalse {
+		t.Fatalf("expected Available condition to be False, got %+v", available)
+	}
+	if available.ObservedGeneration != 5 {
+		t.Fatalf("expected Available ObservedGeneration to be 5, got %d", available.ObservedGeneration)
+	}
+
+	// resource2 is individually Degraded: True, so the aggregate must surface that even
+	// though the other manifest is healthy - AnyFalseIsFalse would have reported False here
+	// because resource1 is Degraded: False.
+	degraded := FindStatusCondition(status.Conditions, "Degraded")
+	if degraded == nil || degraded.Status != metav1.ConditionTrue {
+		t.Fatalf("expected Degraded condition to be True, got %+v", degraded)
+	}
+	if degraded.ObservedGeneration != 5 {
+		t.Fatalf("expected Degraded ObservedGeneration to be 5, got %d", degraded.ObservedGeneration)
+	}
+	if degraded.Reason != "CrashLoopBackOff" {
+		t.Fatalf("expected Degraded reason to be CrashLoopBackOff, got %q", degraded.Reason)
+	}
+}
+
+// TestSummarizeManifestWorkStatusObservedGeneration verifies that re-summarizing at the same
+// generation is a no-op (ObservedGeneration and LastTransitionTime are left untouched), and
+// that a new generation bumps ObservedGeneration instead of resetting it to 0.
+func TestSummarizeManifestWorkStatusObservedGeneration(t *testing.T) {
+	status := &workapiv1.ManifestWorkStatus{
+		ManifestConditions: []workapiv1.ManifestCondition{
+			newManifestCondition(0, "resource1", newCondition("Available", "True", "ResourceAvailable", "my-message", nil)),
+		},
+	}
+
+	SummarizeManifestWorkStatus(status, 5)
+	available := FindStatusCondition(status.Conditions, "Available")
+	if available == nil || available.ObservedGeneration != 5 {
+		t.Fatalf("expected Available ObservedGeneration to be 5, got %+v", available)
+	}
+	firstTransition := available.LastTransitionTime
+
+	SummarizeManifestWorkStatus(status, 5)
+	available = FindStatusCondition(status.Conditions, "Available")
+	if available.ObservedGeneration != 5 {
+		t.Fatalf("expected Available ObservedGeneration to remain 5, got %d", available.ObservedGeneration)
+	}
+	if available.LastTransitionTime != firstTransition {
+		t.Fatalf("expected LastTransitionTime to be unchanged when generation is unchanged")
+	}
+
+	SummarizeManifestWorkStatus(status, 6)
+	available = FindStatusCondition(status.Conditions, "Available")
+	if available.ObservedGeneration != 6 {
+		t.Fatalf("expected Available ObservedGeneration to advance to 6, got %d", available.ObservedGeneration)
+	}
+	if available.LastTransitionTime == firstTransition {
+		t.Fatalf("expected LastTransitionTime to move on once the generation advances")
+	}
+}
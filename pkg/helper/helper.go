@@ -0,0 +1,206 @@
+package helper
+
+import (
+	"context"
+
+	workv1client "github.com/open-cluster-management/api/client/work/clientset/versioned/typed/work/v1"
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpdateManifestWorkStatusFunc is a function that mutates a ManifestWork status in place.
+// observedGeneration is the generation of the ManifestWork being updated, as read at the
+// start of the update; implementations that set conditions should stamp it onto any
+// condition they write so consumers can tell whether the condition reflects the current
+// spec or a stale one.
+type UpdateManifestWorkStatusFunc func(status *workapiv1.ManifestWorkStatus, observedGeneration int64) error
+
+// UpdateManifestWorkStatus fetches the named ManifestWork, applies each of the given update
+// functions to a copy of its status, and writes the result back if anything changed. It
+// returns the resulting status, whether an update was actually persisted, and any error
+// encountered along the way.
+func UpdateManifestWorkStatus(
+	ctx context.Context,
+	client workv1client.ManifestWorkInterface,
+	manifestWorkName string,
+	updateFuncs ...UpdateManifestWorkStatusFunc) (*workapiv1.ManifestWorkStatus, bool, error) {
+	manifestWork, err := client.Get(ctx, manifestWorkName, metav1.GetOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	oldStatus := &manifestWork.Status
+	newStatus := oldStatus.DeepCopy()
+	for _, update := range updateFuncs {
+		if err := update(newStatus, manifestWork.Generation); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if equality.Semantic.DeepEqual(oldStatus, newStatus) {
+		return oldStatus, false, nil
+	}
+
+	manifestWork.Status = *newStatus
+	updatedManifestWork, err := client.UpdateStatus(ctx, manifestWork, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &updatedManifestWork.Status, true, nil
+}
+
+// conditionKeyFunc derives the key used to match an existing condition against an incoming
+// one. The default keys purely on Type, matching the historical, Kubernetes-conventional
+// behavior where a condition Type is unique within a slice.
+type conditionKeyFunc func(workapiv1.StatusCondition) string
+
+func typeKey(condition workapiv1.StatusCondition) string {
+	return condition.Type
+}
+
+func typeAndReasonKey(condition workapiv1.StatusCondition) string {
+	return condition.Type + "/" + condition.Reason
+}
+
+// SetStatusCondition sets the corresponding condition in conditions to newCondition, keyed by
+// Type. If a condition of the same Type already exists, its Status, ObservedGeneration,
+// Reason and Message are overwritten in place; LastTransitionTime is only bumped when Status
+// or ObservedGeneration changes, so a caller can tell whether a True/False reflects the
+// current spec or a stale one. Otherwise newCondition is appended.
+func SetStatusCondition(conditions *[]workapiv1.StatusCondition, newCondition workapiv1.StatusCondition) {
+	setStatusConditionByKey(conditions, newCondition, typeKey)
+}
+
+// FindStatusCondition returns the condition with the given Type, or nil if none exists.
+func FindStatusCondition(conditions []workapiv1.StatusCondition, conditionType string) *workapiv1.StatusCondition {
+	return findStatusConditionByKey(conditions, conditionType, typeKey)
+}
+
+func setStatusConditionByKey(conditions *[]workapiv1.StatusCondition, newCondition workapiv1.StatusCondition, keyFunc conditionKeyFunc) {
+	if conditions == nil {
+		return
+	}
+	existingCondition := findStatusConditionByKey(*conditions, keyFunc(newCondition), keyFunc)
+	if existingCondition == nil {
+		if newCondition.LastTransitionTime.IsZero() {
+			newCondition.LastTransitionTime = metav1.Now()
+		}
+		*conditions = append(*conditions, newCondition)
+		return
+	}
+
+	if existingCondition.Status != newCondition.Status || existingCondition.ObservedGeneration != newCondition.ObservedGeneration {
+		if !newCondition.LastTransitionTime.IsZero() {
+			existingCondition.LastTransitionTime = newCondition.LastTransitionTime
+		} else {
+			existingCondition.LastTransitionTime = metav1.Now()
+		}
+		existingCondition.Status = newCondition.Status
+	}
+
+	existingCondition.ObservedGeneration = newCondition.ObservedGeneration
+	existingCondition.Reason = newCondition.Reason
+	existingCondition.Message = newCondition.Message
+}
+
+func findStatusConditionByKey(conditions []workapiv1.StatusCondition, key string, keyFunc conditionKeyFunc) *workapiv1.StatusCondition {
+	for i := range conditions {
+		if keyFunc(conditions[i]) == key {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// MergeOption customizes the behavior of MergeStatusConditions.
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	keyFunc conditionKeyFunc
+}
+
+// WithTypeAndReasonKey makes MergeStatusConditions key conditions by the pair (Type, Reason)
+// instead of by Type alone, so that two active conditions of the same Type but different
+// Reasons are kept side by side rather than collapsed into one.
+func WithTypeAndReasonKey() MergeOption {
+	return func(c *mergeConfig) {
+		c.keyFunc = typeAndReasonKey
+	}
+}
+
+// MergeStatusConditions returns the result of layering newConditions on top of conditions:
+// every condition in newConditions is applied via SetStatusCondition semantics (so an
+// existing, matching condition keeps its LastTransitionTime when its Status and
+// ObservedGeneration are unchanged),
+// and any condition from the starting set whose key isn't reasserted by newConditions is
+// dropped. By default conditions are keyed by Type; pass WithTypeAndReasonKey() to key by
+// the (Type, Reason) pair instead.
+func MergeStatusConditions(conditions []workapiv1.StatusCondition, newConditions []workapiv1.StatusCondition, opts ...MergeOption) []workapiv1.StatusCondition {
+	cfg := &mergeConfig{keyFunc: typeKey}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	merged := append([]workapiv1.StatusCondition{}, conditions...)
+	for _, newCondition := range newConditions {
+		setStatusConditionByKey(&merged, newCondition, cfg.keyFunc)
+	}
+
+	retained := merged[:0]
+	for _, condition := range merged {
+		if findStatusConditionByKey(newConditions, cfg.keyFunc(condition), cfg.keyFunc) != nil {
+			retained = append(retained, condition)
+		}
+	}
+	return retained
+}
+
+// resourceMetaKey identifies a ManifestCondition by the resource it describes, ignoring its
+// Ordinal (which can shift between reconciles as manifests are added/removed).
+func resourceMetaKey(meta workapiv1.ManifestResourceMeta) workapiv1.ManifestResourceMeta {
+	meta.Ordinal = 0
+	return meta
+}
+
+// MergeManifestConditions merges the per-manifest conditions in newConditions into
+// conditions, matched by resource identity. A matching pair has its Conditions merged via
+// MergeStatusConditions (so per-condition LastTransitionTime is preserved); a manifest
+// present in conditions but absent from newConditions is dropped, since it is no longer part
+// of the ManifestWork.
+func MergeManifestConditions(conditions, newConditions []workapiv1.ManifestCondition) []workapiv1.ManifestCondition {
+	newByResource := map[workapiv1.ManifestResourceMeta]workapiv1.ManifestCondition{}
+	for _, condition := range newConditions {
+		newByResource[resourceMetaKey(condition.ResourceMeta)] = condition
+	}
+
+	merged := []workapiv1.ManifestCondition{}
+	seen := map[workapiv1.ManifestResourceMeta]bool{}
+	for _, condition := range conditions {
+		key := resourceMetaKey(condition.ResourceMeta)
+		newCondition, ok := newByResource[key]
+		if !ok {
+			continue
+		}
+		merged = append(merged, mergeManifestCondition(condition, newCondition))
+		seen[key] = true
+	}
+
+	for _, newCondition := range newConditions {
+		key := resourceMetaKey(newCondition.ResourceMeta)
+		if seen[key] {
+			continue
+		}
+		merged = append(merged, newCondition)
+	}
+
+	return merged
+}
+
+func mergeManifestCondition(old, new workapiv1.ManifestCondition) workapiv1.ManifestCondition {
+	return workapiv1.ManifestCondition{
+		ResourceMeta: new.ResourceMeta,
+		Conditions:   MergeStatusConditions(old.Conditions, new.Conditions),
+	}
+}
@@ -25,8 +25,14 @@ func newCondition(name, status, reason, message string, lastTransition *metav1.T
 	return ret
 }
 
+func withObservedGeneration(cond workapiv1.StatusCondition, generation int64) workapiv1.StatusCondition {
+	cond.ObservedGeneration = generation
+	return cond
+}
+
 func updateSpokeClusterConditionFn(cond workapiv1.StatusCondition) UpdateManifestWorkStatusFunc {
-	return func(oldStatus *workapiv1.ManifestWorkStatus) error {
+	return func(oldStatus *workapiv1.ManifestWorkStatus, observedGeneration int64) error {
+		cond.ObservedGeneration = observedGeneration
 		SetStatusCondition(&oldStatus.Conditions, cond)
 		return nil
 	}
@@ -47,10 +53,15 @@ func TestUpdateStatusCondition(t *testing.T) {
 
 	cases := []struct {
 		name               string
+		generation         int64
 		startingConditions []workapiv1.StatusCondition
 		newCondition       workapiv1.StatusCondition
 		expectedUpdated    bool
 		expectedConditions []workapiv1.StatusCondition
+		// assertTransitionBumpedFrom, when set, additionally asserts that the "one" condition's
+		// LastTransitionTime moved on from this value, since the generic comparison below
+		// zeroes both sides whenever expectedConditions leaves LastTransitionTime unset.
+		assertTransitionBumpedFrom *metav1.Time
 	}{
 		{
 			name:               "add to empty",
@@ -97,12 +108,37 @@ func TestUpdateStatusCondition(t *testing.T) {
 				newCondition("one", "True", "my-reason", "my-message", &beforeish),
 			},
 		},
+		{
+			name:       "observed generation advances despite unchanged status",
+			generation: 2,
+			startingConditions: []workapiv1.StatusCondition{
+				withObservedGeneration(newCondition("one", "True", "my-reason", "my-message", &beforeish), 1),
+			},
+			newCondition:    newCondition("one", "True", "my-reason", "my-message", nil),
+			expectedUpdated: true,
+			expectedConditions: []workapiv1.StatusCondition{
+				withObservedGeneration(newCondition("one", "True", "my-reason", "my-message", nil), 2),
+			},
+			assertTransitionBumpedFrom: &beforeish,
+		},
+		{
+			name:       "no-op when generation is unchanged",
+			generation: 3,
+			startingConditions: []workapiv1.StatusCondition{
+				withObservedGeneration(newCondition("one", "True", "my-reason", "my-message", &beforeish), 3),
+			},
+			newCondition:    newCondition("one", "True", "my-reason", "my-message", nil),
+			expectedUpdated: false,
+			expectedConditions: []workapiv1.StatusCondition{
+				withObservedGeneration(newCondition("one", "True", "my-reason", "my-message", &beforeish), 3),
+			},
+		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
-				ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1", Generation: c.generation},
 				Status: workapiv1.ManifestWorkStatus{
 					Conditions: c.startingConditions,
 				},
@@ -130,6 +166,15 @@ func TestUpdateStatusCondition(t *testing.T) {
 					t.Errorf(diff.ObjectDiff(expected, actual))
 				}
 			}
+			if c.assertTransitionBumpedFrom != nil {
+				actual := FindStatusCondition(status.Conditions, "one")
+				if actual == nil {
+					t.Fatalf("expected condition %q to exist", "one")
+				}
+				if actual.LastTransitionTime == *c.assertTransitionBumpedFrom {
+					t.Errorf("expected LastTransitionTime to move on from %v, but it didn't", c.assertTransitionBumpedFrom)
+				}
+			}
 		})
 	}
 }
@@ -284,3 +329,74 @@ func TestMergeStatusConditions(t *testing.T) {
 		})
 	}
 }
+
+// TestMergeStatusConditionsWithTypeAndReasonKey tests MergeStatusConditions with the
+// WithTypeAndReasonKey option, where two conditions sharing a Type but carrying different
+// Reasons must be kept side by side instead of collapsing into one.
+func TestMergeStatusConditionsWithTypeAndReasonKey(t *testing.T) {
+	transitionTime := metav1.Now()
+
+	cases := []struct {
+		name               string
+		startingConditions []workapiv1.StatusCondition
+		newConditions      []workapiv1.StatusCondition
+		expectedConditions []workapiv1.StatusCondition
+	}{
+		{
+			name: "same type, different reason are kept distinct",
+			newConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "reason-a", "message-a", nil),
+				newCondition("one", "True", "reason-b", "message-b", nil),
+			},
+			expectedConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "reason-a", "message-a", nil),
+				newCondition("one", "True", "reason-b", "message-b", nil),
+			},
+		},
+		{
+			name: "existing reason keeps its transition time",
+			startingConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "reason-a", "message-a", &transitionTime),
+			},
+			newConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "reason-a", "message-a-updated", nil),
+				newCondition("one", "True", "reason-b", "message-b", nil),
+			},
+			expectedConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "reason-a", "message-a-updated", &transitionTime),
+				newCondition("one", "True", "reason-b", "message-b", nil),
+			},
+		},
+		{
+			name: "reason no longer asserted is dropped",
+			startingConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "reason-a", "message-a", nil),
+				newCondition("one", "True", "reason-b", "message-b", &transitionTime),
+			},
+			newConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "reason-b", "message-b", nil),
+			},
+			expectedConditions: []workapiv1.StatusCondition{
+				newCondition("one", "True", "reason-b", "message-b", &transitionTime),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			merged := MergeStatusConditions(c.startingConditions, c.newConditions, WithTypeAndReasonKey())
+			if len(merged) != len(c.expectedConditions) {
+				t.Fatalf("expected %d conditions, got %d", len(c.expectedConditions), len(merged))
+			}
+			for i, expect := range c.expectedConditions {
+				actual := merged[i]
+				if expect.LastTransitionTime == (metav1.Time{}) {
+					actual.LastTransitionTime = metav1.Time{}
+				}
+				if !equality.Semantic.DeepEqual(actual, expect) {
+					t.Errorf(diff.ObjectDiff(actual, expect))
+				}
+			}
+		})
+	}
+}
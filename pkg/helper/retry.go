@@ -0,0 +1,144 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	workv1client "github.com/open-cluster-management/api/client/work/clientset/versioned/typed/work/v1"
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// maxRetryAttempts bounds RetryUpdateManifestWorkStatus/RetryUpdateManifestWorkSpec so a
+// ManifestWork that is perpetually conflicting doesn't retry forever.
+const maxRetryAttempts = 30
+
+// DefaultManifestWorkRateLimiter returns the rate limiter RetryUpdateManifestWorkStatus and
+// RetryUpdateManifestWorkSpec fall back to when no limiter is supplied: a per-item limiter
+// that waits 50ms between the first ~20 attempts before backing off to maxDelay, combined
+// with an overall (not per-item) 5 QPS / burst-20 token bucket. Each call returns a new
+// limiter with its own token bucket, shared only by the callers that reuse that instance.
+func DefaultManifestWorkRateLimiter(maxDelay time.Duration) workqueue.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemFastSlowRateLimiter(50*time.Millisecond, maxDelay, 20),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(5), 20)},
+	)
+}
+
+// UpdateManifestWorkSpecFunc is a function that mutates a ManifestWork spec in place.
+type UpdateManifestWorkSpecFunc func(spec *workapiv1.ManifestWorkSpec) error
+
+// UpdateManifestWorkSpec fetches the named ManifestWork, applies each of the given update
+// functions to a copy of its spec, and writes the result back if anything changed. It
+// returns the resulting spec, whether an update was actually persisted, and any error
+// encountered along the way.
+func UpdateManifestWorkSpec(
+	ctx context.Context,
+	client workv1client.ManifestWorkInterface,
+	manifestWorkName string,
+	updateFuncs ...UpdateManifestWorkSpecFunc) (*workapiv1.ManifestWorkSpec, bool, error) {
+	manifestWork, err := client.Get(ctx, manifestWorkName, metav1.GetOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	oldSpec := &manifestWork.Spec
+	newSpec := oldSpec.DeepCopy()
+	for _, update := range updateFuncs {
+		if err := update(newSpec); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if equality.Semantic.DeepEqual(oldSpec, newSpec) {
+		return oldSpec, false, nil
+	}
+
+	manifestWork.Spec = *newSpec
+	updatedManifestWork, err := client.Update(ctx, manifestWork, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &updatedManifestWork.Spec, true, nil
+}
+
+// RetryUpdateManifestWorkStatus wraps UpdateManifestWorkStatus with a bounded retry loop: on
+// a conflict error it waits out limiter.When(namespace/manifestWorkName) and re-fetches/
+// re-applies update, so the caller doesn't have to reimplement get-mutate-update retry on
+// every call site. Any other error is returned immediately. If limiter is nil,
+// DefaultManifestWorkRateLimiter(time.Second) is used. namespace is only used to key the
+// per-item rate limiter, since limiter is expected to be a long-lived instance shared across
+// ManifestWorks that may collide on name across namespaces.
+func RetryUpdateManifestWorkStatus(
+	ctx context.Context,
+	client workv1client.ManifestWorkInterface,
+	namespace string,
+	manifestWorkName string,
+	update UpdateManifestWorkStatusFunc,
+	limiter workqueue.RateLimiter) (*workapiv1.ManifestWorkStatus, bool, error) {
+	if limiter == nil {
+		limiter = DefaultManifestWorkRateLimiter(time.Second)
+	}
+	limiterKey := namespace + "/" + manifestWorkName
+
+	for attempt := 0; attempt < maxRetryAttempts; attempt++ {
+		status, updated, err := UpdateManifestWorkStatus(ctx, client, manifestWorkName, update)
+		switch {
+		case err == nil:
+			limiter.Forget(limiterKey)
+			return status, updated, nil
+		case !errors.IsConflict(err):
+			return nil, false, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(limiter.When(limiterKey)):
+		}
+	}
+
+	return nil, false, fmt.Errorf("exceeded %d attempts updating status of manifestwork %q", maxRetryAttempts, manifestWorkName)
+}
+
+// RetryUpdateManifestWorkSpec wraps UpdateManifestWorkSpec with the same bounded,
+// rate-limited conflict retry as RetryUpdateManifestWorkStatus. namespace is only used to key
+// the per-item rate limiter, since limiter is expected to be a long-lived instance shared
+// across ManifestWorks that may collide on name across namespaces.
+func RetryUpdateManifestWorkSpec(
+	ctx context.Context,
+	client workv1client.ManifestWorkInterface,
+	namespace string,
+	manifestWorkName string,
+	update UpdateManifestWorkSpecFunc,
+	limiter workqueue.RateLimiter) (*workapiv1.ManifestWorkSpec, bool, error) {
+	if limiter == nil {
+		limiter = DefaultManifestWorkRateLimiter(time.Second)
+	}
+	limiterKey := namespace + "/" + manifestWorkName
+
+	for attempt := 0; attempt < maxRetryAttempts; attempt++ {
+		spec, updated, err := UpdateManifestWorkSpec(ctx, client, manifestWorkName, update)
+		switch {
+		case err == nil:
+			limiter.Forget(limiterKey)
+			return spec, updated, nil
+		case !errors.IsConflict(err):
+			return nil, false, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(limiter.When(limiterKey)):
+		}
+	}
+
+	return nil, false, fmt.Errorf("exceeded %d attempts updating spec of manifestwork %q", maxRetryAttempts, manifestWorkName)
+}
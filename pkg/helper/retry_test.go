@@ -0,0 +1,118 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	fakeworkclient "github.com/open-cluster-management/api/client/work/clientset/versioned/fake"
+	workapiv1 "github.com/open-cluster-management/api/work/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+// conflictThenSucceed fails the first failures update/updateStatus calls against
+// manifestworks with a conflict error, then lets the rest through.
+func conflictThenSucceed(failures int) func(action clienttesting.Action) (bool, runtime.Object, error) {
+	attempts := 0
+	return func(action clienttesting.Action) (bool, runtime.Object, error) {
+		attempts++
+		if attempts <= failures {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "manifestworks"}, "work1", fmt.Errorf("conflict on attempt %d", attempts))
+		}
+		return false, nil, nil
+	}
+}
+
+// TestRetryUpdateManifestWorkStatus tests RetryUpdateManifestWorkStatus
+func TestRetryUpdateManifestWorkStatus(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+	})
+	fakeWorkClient.PrependReactor("update", "manifestworks", conflictThenSucceed(2))
+
+	status, updated, err := RetryUpdateManifestWorkStatus(
+		context.TODO(),
+		fakeWorkClient.WorkV1().ManifestWorks("cluster1"),
+		"cluster1",
+		"work1",
+		func(status *workapiv1.ManifestWorkStatus, observedGeneration int64) error {
+			SetStatusCondition(&status.Conditions, newCondition("Applied", "True", "my-reason", "my-message", nil))
+			return nil
+		},
+		DefaultManifestWorkRateLimiter(10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !updated {
+		t.Fatalf("expected status to be updated")
+	}
+	if FindStatusCondition(status.Conditions, "Applied") == nil {
+		t.Fatalf("expected Applied condition to be set, got %+v", status.Conditions)
+	}
+}
+
+// TestRetryUpdateManifestWorkStatusNonConflictError tests that a non-conflict error is
+// returned immediately without retrying.
+func TestRetryUpdateManifestWorkStatusNonConflictError(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+	})
+	attempts := 0
+	fakeWorkClient.PrependReactor("update", "manifestworks", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		attempts++
+		return true, nil, apierrors.NewInternalError(fmt.Errorf("boom"))
+	})
+
+	_, _, err := RetryUpdateManifestWorkStatus(
+		context.TODO(),
+		fakeWorkClient.WorkV1().ManifestWorks("cluster1"),
+		"cluster1",
+		"work1",
+		func(status *workapiv1.ManifestWorkStatus, observedGeneration int64) error {
+			SetStatusCondition(&status.Conditions, newCondition("Applied", "True", "my-reason", "my-message", nil))
+			return nil
+		},
+		DefaultManifestWorkRateLimiter(10*time.Millisecond),
+	)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly one attempt, got %d", attempts)
+	}
+}
+
+// TestRetryUpdateManifestWorkSpec tests RetryUpdateManifestWorkSpec
+func TestRetryUpdateManifestWorkSpec(t *testing.T) {
+	fakeWorkClient := fakeworkclient.NewSimpleClientset(&workapiv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{Name: "work1", Namespace: "cluster1"},
+	})
+	fakeWorkClient.PrependReactor("update", "manifestworks", conflictThenSucceed(3))
+
+	spec, updated, err := RetryUpdateManifestWorkSpec(
+		context.TODO(),
+		fakeWorkClient.WorkV1().ManifestWorks("cluster1"),
+		"cluster1",
+		"work1",
+		func(spec *workapiv1.ManifestWorkSpec) error {
+			spec.Workload.Manifests = []workapiv1.Manifest{{}}
+			return nil
+		},
+		DefaultManifestWorkRateLimiter(10*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !updated {
+		t.Fatalf("expected spec to be updated")
+	}
+	if len(spec.Workload.Manifests) != 1 {
+		t.Fatalf("expected one manifest, got %d", len(spec.Workload.Manifests))
+	}
+}